@@ -0,0 +1,51 @@
+// Command osm-meshconfig-convert reads a legacy "osm-config" ConfigMap as YAML and prints the equivalent
+// MeshConfig custom resource, to help operators migrate off the ConfigMap-based configuration.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-service-mesh/osm/pkg/configurator"
+)
+
+func main() {
+	configMapFile := flag.String("configmap-file", "", "Path to a YAML file containing the osm-config ConfigMap")
+	flag.Parse()
+
+	if *configMapFile == "" {
+		fmt.Fprintln(os.Stderr, "-configmap-file is required")
+		os.Exit(1)
+	}
+
+	data, err := ioutil.ReadFile(*configMapFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading %s: %s\n", *configMapFile, err)
+		os.Exit(1)
+	}
+
+	var configMap v1.ConfigMap
+	if err := yaml.Unmarshal(data, &configMap); err != nil {
+		fmt.Fprintf(os.Stderr, "error unmarshaling %s: %s\n", *configMapFile, err)
+		os.Exit(1)
+	}
+
+	meshConfig, err := configurator.ConvertConfigMapToMeshConfig(&configMap)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error converting ConfigMap to MeshConfig: %s\n", err)
+		os.Exit(1)
+	}
+
+	out, err := yaml.Marshal(meshConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error marshaling MeshConfig: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Print(string(out))
+}
@@ -0,0 +1,74 @@
+// Package v1alpha1 contains the v1alpha1 API group for OSM's config.openservicemesh.io custom resources.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeshConfig is the type used to represent the mesh-wide configuration for OSM. It supersedes the "osm-config"
+// ConfigMap, giving mesh tunables schema validation, `kubectl explain` and versioning.
+type MeshConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the MeshConfig specification.
+	// +optional
+	Spec MeshConfigSpec `json:"spec,omitempty"`
+}
+
+// MeshConfigSpec is the spec for OSM's mesh-wide configuration. Its fields mirror the "osm-config" ConfigMap's
+// Data keys one to one. PermissiveTrafficPolicyMode is a plain bool here, so an explicit false is not told apart
+// from an unset field.
+type MeshConfigSpec struct {
+	// ConfigVersion is an optional field showing the version of the config applied, used for debug purposes.
+	// +optional
+	ConfigVersion int `json:"config_version,omitempty" yaml:"config_version,omitempty"`
+
+	// PermissiveTrafficPolicyMode is a bool toggle, which when TRUE ignores SMI policies and allows existing
+	// Kubernetes services to communicate with each other uninterrupted.
+	// +optional
+	PermissiveTrafficPolicyMode bool `json:"permissive_traffic_policy_mode,omitempty" yaml:"permissive_traffic_policy_mode,omitempty"`
+
+	// TracingHost is the host name of the tracing collector the mesh's Envoy proxies should export spans to.
+	// +optional
+	TracingHost string `json:"tracing_host,omitempty" yaml:"tracing_host,omitempty"`
+
+	// TracingPort is the port of the tracing collector.
+	// +optional
+	TracingPort int `json:"tracing_port,omitempty" yaml:"tracing_port,omitempty"`
+
+	// TracingEndpoint is the HTTP endpoint the tracing collector receives spans on, e.g. "/api/v2/spans".
+	// +optional
+	TracingEndpoint string `json:"tracing_endpoint,omitempty" yaml:"tracing_endpoint,omitempty"`
+
+	// MeshCIDRRanges is the list of CIDR ranges considered to be inside the mesh.
+	// +optional
+	MeshCIDRRanges []string `json:"mesh_cidr_ranges,omitempty" yaml:"mesh_cidr_ranges,omitempty"`
+
+	// EnvoyLogLevel is the log level OSM configures its Envoy sidecars to run with, e.g. "info" or "debug".
+	// +optional
+	EnvoyLogLevel string `json:"envoy_log_level,omitempty" yaml:"envoy_log_level,omitempty"`
+
+	// ServiceCertValidityDuration is how long a service certificate issued by OSM remains valid, expressed as a
+	// Go duration string, e.g. "24h".
+	// +optional
+	ServiceCertValidityDuration string `json:"service_cert_validity_duration,omitempty" yaml:"service_cert_validity_duration,omitempty"`
+
+	// EgressPolicy determines how the mesh treats traffic to destinations outside the mesh.
+	// +optional
+	// +kubebuilder:validation:Enum=disabled;allow_all;allow_listed
+	EgressPolicy string `json:"egress_policy,omitempty" yaml:"egress_policy,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeshConfigList is a list of MeshConfig resources.
+type MeshConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MeshConfig `json:"items"`
+}
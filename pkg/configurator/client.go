@@ -1,11 +1,15 @@
 package configurator
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
-	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -13,17 +17,31 @@ import (
 	k8s "github.com/open-service-mesh/osm/pkg/kubernetes"
 )
 
-// NewConfigurator implements configurator.Configurator and creates the Kubernetes client to manage namespaces.
-func NewConfigurator(kubeClient kubernetes.Interface, stop chan struct{}, osmNamespace, osmConfigMapName string) Configurator {
+// osmMeshConfigName is the fixed name of the MeshConfig CR OSM reads, analogous to osmConfigMapName for the
+// ConfigMap-based provider.
+const osmMeshConfigName = "osm-mesh-config"
+
+// newConfigMapConfigurator implements configurator.Configurator backed by the "osm-config" ConfigMap and, when
+// present, the "osm-mesh-config" MeshConfig custom resource, both watched via Kubernetes informers. It is one of
+// the sources NewConfigurator may compose into a LayeredConfigurator.
+func newConfigMapConfigurator(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, stop chan struct{}, osmNamespace, osmConfigMapName string) Configurator {
 	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
 	informer := informerFactory.Core().V1().ConfigMaps().Informer()
+
+	dynamicInformerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicClient, k8s.DefaultKubeEventResyncInterval, osmNamespace, nil)
+	meshConfigInformer := dynamicInformerFactory.ForResource(meshConfigGVR).Informer()
+
 	client := Client{
-		informer:         informer,
-		cache:            informer.GetStore(),
-		cacheSynced:      make(chan interface{}),
-		announcements:    make(chan interface{}),
-		osmNamespace:     osmNamespace,
-		osmConfigMapName: osmConfigMapName,
+		informer:           informer,
+		cache:              informer.GetStore(),
+		meshConfigInformer: meshConfigInformer,
+		meshConfigCache:    meshConfigInformer.GetStore(),
+		cacheSynced:        make(chan interface{}),
+		announcements:      make(chan interface{}),
+		osmNamespace:       osmNamespace,
+		osmConfigMapName:   osmConfigMapName,
+		subscribers:        make(map[string]map[chan ConfigChange]struct{}),
+		recorder:           newConfigMapEventRecorder(kubeClient, osmNamespace),
 	}
 
 	// Ensure this only watches the Namespace where OSM in installed
@@ -31,77 +49,275 @@ func NewConfigurator(kubeClient kubernetes.Interface, stop chan struct{}, osmNam
 		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
 		return ns == osmNamespace
 	}
+	informer.AddEventHandler(k8s.GetKubernetesEventHandlers("ConfigMap", "OSMConfigMap", client.announcements, shouldObserve))
 
-	informerName := "ConfigMap"
-	providerName := "OSMConfigMap"
-	informer.AddEventHandler(k8s.GetKubernetesEventHandlers(informerName, providerName, client.announcements, shouldObserve))
+	// unstructured.Unstructured does not promote ObjectMeta the way typed objects do, so it needs its own filter.
+	shouldObserveMeshConfig := func(obj interface{}) bool {
+		u, ok := obj.(*unstructured.Unstructured)
+		return ok && u.GetNamespace() == osmNamespace
+	}
+	meshConfigInformer.AddEventHandler(k8s.GetKubernetesEventHandlers("MeshConfig", "OSMMeshConfig", client.announcements, shouldObserveMeshConfig))
 
 	go client.run(stop)
 
 	return &client
 }
 
-// This struct must match the shape of the "osm-config" ConfigMap
-// which was created in the OSM namespace.
+// This struct must match the shape of the "osm-config" ConfigMap and the spec of the MeshConfig CRD, both of
+// which are created in the OSM namespace. json tags let it double as the target of a MeshConfig CR's spec, decoded
+// from the dynamic client's unstructured.Unstructured; yaml tags are used by the file-backed Configurator and, via
+// yamlFieldName, as the field names in ConfigChange and Subscribe. The ConfigMap itself is decoded key-by-key via
+// configMapKeyParsers.
 type osmConfig struct {
 
 	// ConfigVersion is optional field, which shows the version of the config applied.
 	// This is used for debug purposes.
-	ConfigVersion int `yaml:"config_version"`
+	ConfigVersion int `yaml:"config_version" json:"config_version,omitempty"`
 
 	// PermissiveTrafficPolicyMode is a bool toggle, which when TRUE ignores SMI policies and
 	// allows existing Kubernetes services to communicate with each other uninterrupted.
 	// This is useful whet set TRUE in brownfield configurations, where we first want to observe
 	// existing traffic patterns.
-	PermissiveTrafficPolicyMode bool `yaml:"permissive_traffic_policy_mode"`
+	// It is a pointer so LayeredConfigurator can tell "never set" (nil) apart from "explicitly set to false".
+	PermissiveTrafficPolicyMode *bool `yaml:"permissive_traffic_policy_mode,omitempty" json:"permissive_traffic_policy_mode,omitempty"`
+
+	// TracingHost is the host name of the tracing collector the mesh's Envoy proxies should export spans to.
+	TracingHost string `yaml:"tracing_host" json:"tracing_host,omitempty"`
+
+	// TracingPort is the port of the tracing collector.
+	TracingPort int `yaml:"tracing_port" json:"tracing_port,omitempty"`
+
+	// TracingEndpoint is the HTTP endpoint the tracing collector receives spans on, e.g. "/api/v2/spans".
+	TracingEndpoint string `yaml:"tracing_endpoint" json:"tracing_endpoint,omitempty"`
+
+	// MeshCIDRRanges is the list of CIDR ranges considered to be inside the mesh, used to distinguish in-mesh
+	// traffic from egress.
+	MeshCIDRRanges []string `yaml:"mesh_cidr_ranges" json:"mesh_cidr_ranges,omitempty"`
+
+	// EnvoyLogLevel is the log level OSM configures its Envoy sidecars to run with, e.g. "info" or "debug".
+	EnvoyLogLevel string `yaml:"envoy_log_level" json:"envoy_log_level,omitempty"`
+
+	// ServiceCertValidityDuration is how long a service certificate issued by OSM remains valid, expressed as a
+	// Go duration string, e.g. "24h".
+	ServiceCertValidityDuration string `yaml:"service_cert_validity_duration" json:"service_cert_validity_duration,omitempty"`
+
+	// EgressPolicy determines how the mesh treats traffic to destinations outside the mesh. Must be one of the
+	// EgressMode constants.
+	EgressPolicy string `yaml:"egress_policy" json:"egress_policy,omitempty"`
 }
 
 func (c *Client) run(stop <-chan struct{}) {
 	go c.informer.Run(stop)
+	go c.meshConfigInformer.Run(stop)
+
+	// Start fanning out ConfigChange events before waiting on the cache sync below: the informers' initial Lists
+	// are delivered as Add events on c.announcements, and nothing else drains that channel.
+	go c.watchForConfigMapChanges(stop)
+
 	log.Info().Msgf("Started OSM ConfigMap informer - watching for %s", c.getConfigMapCacheKey())
-	log.Info().Msg("[ConfigMap Client] Waiting for ConfigMap informer's cache to sync")
-	if !cache.WaitForCacheSync(stop, c.informer.HasSynced) {
-		log.Error().Msg("Failed initial cache sync for ConfigMap informer")
+	log.Info().Msgf("Started OSM MeshConfig informer - watching for %s", c.getMeshConfigCacheKey())
+	log.Info().Msg("[ConfigMap Client] Waiting for ConfigMap and MeshConfig informer caches to sync")
+	if !cache.WaitForCacheSync(stop, c.informer.HasSynced, c.meshConfigInformer.HasSynced) {
+		log.Error().Msg("Failed initial cache sync for ConfigMap/MeshConfig informers")
 		return
 	}
 
 	// Closing the cacheSynced channel signals to the rest of the system that caches have been synced.
 	close(c.cacheSynced)
-	log.Info().Msg("[ConfigMap Client] Cache sync for ConfigMap informer finished")
+	log.Info().Msg("[ConfigMap Client] Cache sync for ConfigMap and MeshConfig informers finished")
 }
 
 func (c *Client) getConfigMapCacheKey() string {
 	return fmt.Sprintf("%s/%s", c.osmNamespace, c.osmConfigMapName)
 }
 
-func (c *Client) getConfigMap() *osmConfig {
+func (c *Client) getMeshConfigCacheKey() string {
+	return fmt.Sprintf("%s/%s", c.osmNamespace, osmMeshConfigName)
+}
+
+// watchForConfigMapChanges drains c.announcements, re-decoding and diffing the ConfigMap on every Add/Update event
+// until stop is closed.
+func (c *Client) watchForConfigMapChanges(stop <-chan struct{}) {
+	for {
+		select {
+		case <-c.announcements:
+			c.refreshConfig()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// refreshConfig decodes the current "osm-config" ConfigMap, diffs it against the last decoded version, updates the
+// cache and dispatches a ConfigChange to subscribers for every field that changed.
+func (c *Client) refreshConfig() {
+	newConfig, err := c.decodeConfigMap()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error decoding ConfigMap %s; keeping last-known-good config", c.getConfigMapCacheKey())
+		return
+	}
+
+	if err := Validate(newConfig); err != nil {
+		log.Error().Err(err).Msgf("Invalid ConfigMap %s; keeping last-known-good config", c.getConfigMapCacheKey())
+		c.recorder.Eventf(c.configMapObjectReference(), v1.EventTypeWarning, "InvalidConfig", "Rejected osm-config update: %s", err)
+		return
+	}
+
+	c.configMutex.Lock()
+	oldConfig := c.lastConfig
+	c.lastConfig = newConfig
+	c.configMutex.Unlock()
+
+	for _, change := range diffOsmConfig(oldConfig, newConfig) {
+		c.dispatch(change)
+	}
+}
+
+// decodeConfigMap returns the mesh's current configuration, preferring the "osm-mesh-config" MeshConfig CR over
+// the "osm-config" ConfigMap when the former is present, for backward compatibility with ConfigMap-only installs.
+func (c *Client) decodeConfigMap() (*osmConfig, error) {
+	meshConfig, exists, err := c.decodeMeshConfig()
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return meshConfig, nil
+	}
+
+	return c.decodeLegacyConfigMap()
+}
+
+// decodeMeshConfig fetches the "osm-mesh-config" MeshConfig CR from the dynamic informer's cache, if present, and
+// unmarshals its spec.
+func (c *Client) decodeMeshConfig() (conf *osmConfig, exists bool, err error) {
+	meshConfigCacheKey := c.getMeshConfigCacheKey()
+	item, exists, err := c.meshConfigCache.GetByKey(meshConfigCacheKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting MeshConfig by key=%s from cache: %w", meshConfigCacheKey, err)
+	}
+	if !exists {
+		return nil, false, nil
+	}
+
+	unstructuredMeshConfig, ok := item.(*unstructured.Unstructured)
+	if !ok {
+		return nil, false, fmt.Errorf("cached MeshConfig %s is not unstructured.Unstructured", meshConfigCacheKey)
+	}
+
+	spec, found, err := unstructured.NestedMap(unstructuredMeshConfig.Object, "spec")
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading spec of MeshConfig %s: %w", meshConfigCacheKey, err)
+	}
+	if !found {
+		return &osmConfig{}, true, nil
+	}
+
+	rawSpec, err := json.Marshal(spec)
+	if err != nil {
+		return nil, false, fmt.Errorf("error marshaling spec of MeshConfig %s: %w", meshConfigCacheKey, err)
+	}
+
+	conf = &osmConfig{}
+	if err := json.Unmarshal(rawSpec, conf); err != nil {
+		return nil, false, fmt.Errorf("error unmarshaling spec of MeshConfig %s: %w", meshConfigCacheKey, err)
+	}
+
+	return conf, true, nil
+}
+
+// decodeLegacyConfigMap fetches the "osm-config" ConfigMap from the informer's cache and parses each Data entry
+// independently via parseConfigMapData, rather than unmarshaling the whole Data map as a single YAML blob.
+func (c *Client) decodeLegacyConfigMap() (*osmConfig, error) {
 	configMapCacheKey := c.getConfigMapCacheKey()
 	item, exists, err := c.cache.GetByKey(configMapCacheKey)
 	if err != nil {
-		log.Error().Err(err).Msgf("Error getting ConfigMap by key=%s from cache", configMapCacheKey)
+		return nil, fmt.Errorf("error getting ConfigMap by key=%s from cache: %w", configMapCacheKey, err)
 	}
 
 	if !exists {
-		return &osmConfig{}
+		return &osmConfig{}, nil
 	}
 
 	configMap := item.(*v1.ConfigMap)
 
 	if len(configMap.Data) == 0 {
 		log.Error().Msgf("The ConfigMap %s does not contain any Data", configMapCacheKey)
+		return &osmConfig{}, nil
+	}
+
+	return parseConfigMapData(configMap.Data, configMapCacheKey)
+}
+
+// getConfigMap returns the most recently decoded "osm-config" ConfigMap.
+func (c *Client) getConfigMap() *osmConfig {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	if c.lastConfig == nil {
 		return &osmConfig{}
 	}
+	return c.lastConfig
+}
 
-	var config []byte
-	for _, cfg := range configMap.Data {
-		config = []byte(cfg)
+// GetPermissiveTrafficPolicyMode tells us whether the OSM Control Plane is configured to run in permissive mode,
+// defaulting to false when unset.
+func (c *Client) GetPermissiveTrafficPolicyMode() bool {
+	if mode := c.getConfigMap().PermissiveTrafficPolicyMode; mode != nil {
+		return *mode
 	}
+	return false
+}
+
+// rawConfig exposes the undecorated osmConfig to LayeredConfigurator, which needs to distinguish "never set" from
+// "explicitly set to the same value as the default" when merging multiple sources.
+func (c *Client) rawConfig() *osmConfig {
+	return c.getConfigMap()
+}
 
-	conf := osmConfig{}
-	err = yaml.Unmarshal(config, &conf)
+// GetAnnouncementsChannel returns the channel on which ConfigMap changes are announced.
+func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
+	return c.announcements
+}
+
+// GetTracingEndpoint returns the host, port and HTTP endpoint of the tracing collector configured for the mesh.
+func (c *Client) GetTracingEndpoint() (host string, port int, endpoint string) {
+	conf := c.getConfigMap()
+	return conf.TracingHost, conf.TracingPort, conf.TracingEndpoint
+}
+
+// GetMeshCIDRRanges returns the list of CIDR ranges considered to be inside the mesh.
+func (c *Client) GetMeshCIDRRanges() []string {
+	return c.getConfigMap().MeshCIDRRanges
+}
+
+// GetEnvoyLogLevel returns the configured Envoy log level, defaulting to defaultEnvoyLogLevel when unset.
+func (c *Client) GetEnvoyLogLevel() string {
+	if level := c.getConfigMap().EnvoyLogLevel; level != "" {
+		return level
+	}
+	return defaultEnvoyLogLevel
+}
+
+// GetServiceCertValidityDuration returns how long a service certificate issued by OSM remains valid, defaulting to
+// defaultServiceCertValidityDuration when unset (Validate guarantees this field parses if it is set).
+func (c *Client) GetServiceCertValidityDuration() time.Duration {
+	raw := c.getConfigMap().ServiceCertValidityDuration
+	if raw == "" {
+		return defaultServiceCertValidityDuration
+	}
+
+	duration, err := time.ParseDuration(raw)
 	if err != nil {
-		log.Error().Err(err).Msgf("Error marshaling ConfigMap %s with content %s", c.osmConfigMapName, string(config))
+		log.Error().Err(err).Msgf("Error parsing service_cert_validity_duration=%s, using default=%s", raw, defaultServiceCertValidityDuration)
+		return defaultServiceCertValidityDuration
 	}
+	return duration
+}
 
-	return &conf
-}
\ No newline at end of file
+// GetEgressPolicy returns the mesh's configured egress mode, defaulting to defaultEgressPolicy when unset.
+func (c *Client) GetEgressPolicy() EgressMode {
+	if policy := EgressMode(c.getConfigMap().EgressPolicy); policy != "" {
+		return policy
+	}
+	return defaultEgressPolicy
+}
@@ -0,0 +1,92 @@
+package configurator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// configMapKeyParsers maps each "osm-config" ConfigMap Data key to a function that parses and applies its value
+// onto an osmConfig. Treating each key independently, rather than unmarshaling the whole Data map as a single YAML
+// blob, lets `kubectl patch configmap osm-config --type merge -p '{"data":{"envoy_log_level":"debug"}}'` update one
+// setting without having to resupply every other one.
+var configMapKeyParsers = map[string]func(conf *osmConfig, value string) error{
+	"config_version": func(conf *osmConfig, value string) error {
+		version, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid config_version %q: %w", value, err)
+		}
+		conf.ConfigVersion = version
+		return nil
+	},
+
+	"permissive_traffic_policy_mode": func(conf *osmConfig, value string) error {
+		mode, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid permissive_traffic_policy_mode %q: %w", value, err)
+		}
+		conf.PermissiveTrafficPolicyMode = &mode
+		return nil
+	},
+
+	"tracing_host": func(conf *osmConfig, value string) error {
+		conf.TracingHost = value
+		return nil
+	},
+
+	"tracing_port": func(conf *osmConfig, value string) error {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid tracing_port %q: %w", value, err)
+		}
+		conf.TracingPort = port
+		return nil
+	},
+
+	"tracing_endpoint": func(conf *osmConfig, value string) error {
+		conf.TracingEndpoint = value
+		return nil
+	},
+
+	"mesh_cidr_ranges": func(conf *osmConfig, value string) error {
+		conf.MeshCIDRRanges = strings.Split(value, ",")
+		return nil
+	},
+
+	"envoy_log_level": func(conf *osmConfig, value string) error {
+		conf.EnvoyLogLevel = value
+		return nil
+	},
+
+	"service_cert_validity_duration": func(conf *osmConfig, value string) error {
+		conf.ServiceCertValidityDuration = value
+		return nil
+	},
+
+	"egress_policy": func(conf *osmConfig, value string) error {
+		conf.EgressPolicy = value
+		return nil
+	},
+}
+
+// parseConfigMapData parses every entry of an "osm-config" ConfigMap's Data map independently via
+// configMapKeyParsers, rather than unmarshaling the whole map as a single YAML blob (which silently keeps only one
+// key when Data has more than one, since map iteration order is random). cacheKey is used only to attribute log
+// lines and errors to the ConfigMap they came from; it has no effect on parsing. Shared by decodeLegacyConfigMap
+// and ConvertConfigMapToMeshConfig so both apply the same per-key semantics.
+func parseConfigMapData(data map[string]string, cacheKey string) (*osmConfig, error) {
+	conf := &osmConfig{}
+
+	for key, value := range data {
+		parse, known := configMapKeyParsers[key]
+		if !known {
+			log.Error().Msgf("Ignoring unknown key=%s in ConfigMap %s", key, cacheKey)
+			continue
+		}
+		if err := parse(conf, value); err != nil {
+			return nil, fmt.Errorf("error parsing key=%s in ConfigMap %s: %w", key, cacheKey, err)
+		}
+	}
+
+	return conf, nil
+}
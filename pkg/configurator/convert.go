@@ -0,0 +1,45 @@
+package configurator
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-service-mesh/osm/pkg/apis/config/v1alpha1"
+)
+
+// ConvertConfigMapToMeshConfig converts the legacy "osm-config" ConfigMap into the equivalent MeshConfig custom
+// resource, for operators migrating off the ConfigMap-based configuration. It parses configMap.Data the same way
+// decodeLegacyConfigMap does, key by key, rather than unmarshaling the Data map as a single YAML blob -- the latter
+// keeps only one of the map's keys, in map iteration order, once a ConfigMap has more than one.
+func ConvertConfigMapToMeshConfig(configMap *v1.ConfigMap) (*v1alpha1.MeshConfig, error) {
+	conf, err := parseConfigMapData(configMap.Data, fmt.Sprintf("%s/%s", configMap.Namespace, configMap.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	meshConfig := &v1alpha1.MeshConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "MeshConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      osmMeshConfigName,
+			Namespace: configMap.Namespace,
+		},
+		Spec: v1alpha1.MeshConfigSpec{
+			ConfigVersion:               conf.ConfigVersion,
+			PermissiveTrafficPolicyMode: conf.PermissiveTrafficPolicyMode != nil && *conf.PermissiveTrafficPolicyMode,
+			TracingHost:                 conf.TracingHost,
+			TracingPort:                 conf.TracingPort,
+			TracingEndpoint:             conf.TracingEndpoint,
+			MeshCIDRRanges:              conf.MeshCIDRRanges,
+			EnvoyLogLevel:               conf.EnvoyLogLevel,
+			ServiceCertValidityDuration: conf.ServiceCertValidityDuration,
+			EgressPolicy:                conf.EgressPolicy,
+		},
+	}
+
+	return meshConfig, nil
+}
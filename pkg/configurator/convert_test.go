@@ -0,0 +1,73 @@
+package configurator
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestConvertConfigMapToMeshConfig guards against ConvertConfigMapToMeshConfig regressing to unmarshaling
+// configMap.Data as a single YAML blob: since Go map iteration order is random, that approach silently kept only
+// one of several Data keys. Asserting on more than one key here fails if that bug reappears.
+func TestConvertConfigMapToMeshConfig(t *testing.T) {
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "osm-config",
+			Namespace: "osm-system",
+		},
+		Data: map[string]string{
+			"permissive_traffic_policy_mode": "true",
+			"envoy_log_level":                "debug",
+			"egress_policy":                  "allow_all",
+			"tracing_host":                   "jaeger.osm-system.svc.cluster.local",
+			"mesh_cidr_ranges":               "10.0.0.0/8,192.168.0.0/16",
+		},
+	}
+
+	meshConfig, err := ConvertConfigMapToMeshConfig(configMap)
+	if err != nil {
+		t.Fatalf("ConvertConfigMapToMeshConfig() returned an error: %s", err)
+	}
+
+	if !meshConfig.Spec.PermissiveTrafficPolicyMode {
+		t.Error("expected permissive_traffic_policy_mode=true to survive conversion")
+	}
+	if meshConfig.Spec.EnvoyLogLevel != "debug" {
+		t.Errorf("expected envoy_log_level=debug to survive conversion, got %q", meshConfig.Spec.EnvoyLogLevel)
+	}
+	if meshConfig.Spec.EgressPolicy != "allow_all" {
+		t.Errorf("expected egress_policy=allow_all to survive conversion, got %q", meshConfig.Spec.EgressPolicy)
+	}
+	if meshConfig.Spec.TracingHost != "jaeger.osm-system.svc.cluster.local" {
+		t.Errorf("expected tracing_host to survive conversion, got %q", meshConfig.Spec.TracingHost)
+	}
+	if len(meshConfig.Spec.MeshCIDRRanges) != 2 {
+		t.Errorf("expected 2 mesh_cidr_ranges entries to survive conversion, got %v", meshConfig.Spec.MeshCIDRRanges)
+	}
+
+	if meshConfig.Name != osmMeshConfigName {
+		t.Errorf("expected converted MeshConfig name %q, got %q", osmMeshConfigName, meshConfig.Name)
+	}
+	if meshConfig.Namespace != configMap.Namespace {
+		t.Errorf("expected converted MeshConfig namespace %q, got %q", configMap.Namespace, meshConfig.Namespace)
+	}
+}
+
+func TestConvertConfigMapToMeshConfigIgnoresUnknownKey(t *testing.T) {
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "osm-config", Namespace: "osm-system"},
+		Data: map[string]string{
+			"envoy_log_level": "debug",
+			"bogus_key":       "value",
+		},
+	}
+
+	meshConfig, err := ConvertConfigMapToMeshConfig(configMap)
+	if err != nil {
+		t.Fatalf("ConvertConfigMapToMeshConfig() returned an error: %s", err)
+	}
+	if meshConfig.Spec.EnvoyLogLevel != "debug" {
+		t.Errorf("expected envoy_log_level=debug to survive conversion alongside an unknown key, got %q", meshConfig.Spec.EnvoyLogLevel)
+	}
+}
@@ -0,0 +1,12 @@
+package configurator
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// meshConfigGVR identifies the MeshConfig custom resource watched alongside the "osm-config" ConfigMap.
+var meshConfigGVR = schema.GroupVersionResource{
+	Group:    "config.openservicemesh.io",
+	Version:  "v1alpha1",
+	Resource: "meshconfigs",
+}
@@ -0,0 +1,29 @@
+package configurator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// newConfigMapEventRecorder builds an EventRecorder used to surface rejected "osm-config" updates via
+// `kubectl describe configmap osm-config`, instead of only a log line.
+func newConfigMapEventRecorder(kubeClient kubernetes.Interface, osmNamespace string) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartLogging(func(format string, args ...interface{}) {
+		log.Info().Msgf(format, args...)
+	})
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events(osmNamespace)})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "osm-configurator"})
+}
+
+// configMapObjectReference is the object the Configurator attaches rejected-update Events to.
+func (c *Client) configMapObjectReference() *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		Kind:      "ConfigMap",
+		Namespace: c.osmNamespace,
+		Name:      c.osmConfigMapName,
+	}
+}
@@ -0,0 +1,177 @@
+package configurator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v2"
+)
+
+// fileConfigurator is a Configurator backed by a YAML file on disk, live-reloaded via fsnotify. It implements the
+// same osmConfig shape as the ConfigMap provider, so an operator can run OSM against a plain file in environments
+// where the ConfigMap informer cannot be used.
+type fileConfigurator struct {
+	path string
+
+	mu     sync.RWMutex
+	config *osmConfig
+
+	announcements chan interface{}
+}
+
+// newFileConfigurator reads path once synchronously, then watches it for further edits until stop is closed.
+func newFileConfigurator(path string, stop <-chan struct{}) (*fileConfigurator, error) {
+	fc := &fileConfigurator{
+		path:          path,
+		announcements: make(chan interface{}),
+	}
+
+	if err := fc.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("error creating fsnotify watcher for %s: %w", path, err)
+	}
+
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("error watching %s: %w", path, err)
+	}
+
+	go fc.watch(watcher, stop)
+
+	return fc, nil
+}
+
+func (fc *fileConfigurator) watch(watcher *fsnotify.Watcher, stop <-chan struct{}) {
+	defer watcher.Close()
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := fc.reload(); err != nil {
+				log.Error().Err(err).Msgf("Error reloading %s; keeping last-known-good config", fc.path)
+				continue
+			}
+
+			select {
+			case fc.announcements <- struct{}{}:
+			default:
+				log.Warn().Msgf("Announcements channel full for file-backed config %s; dropping change notification", fc.path)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Error().Err(err).Msgf("fsnotify error watching %s", fc.path)
+
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (fc *fileConfigurator) reload() error {
+	data, err := ioutil.ReadFile(fc.path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", fc.path, err)
+	}
+
+	conf := osmConfig{}
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		return fmt.Errorf("error unmarshaling %s: %w", fc.path, err)
+	}
+
+	if err := Validate(&conf); err != nil {
+		return fmt.Errorf("invalid config in %s: %w", fc.path, err)
+	}
+
+	fc.mu.Lock()
+	fc.config = &conf
+	fc.mu.Unlock()
+
+	return nil
+}
+
+func (fc *fileConfigurator) get() *osmConfig {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+
+	if fc.config == nil {
+		return &osmConfig{}
+	}
+	return fc.config
+}
+
+func (fc *fileConfigurator) GetPermissiveTrafficPolicyMode() bool {
+	if mode := fc.get().PermissiveTrafficPolicyMode; mode != nil {
+		return *mode
+	}
+	return false
+}
+
+// rawConfig exposes the undecorated osmConfig to LayeredConfigurator, which needs to distinguish "never set" from
+// "explicitly set to the same value as the default" when merging multiple sources.
+func (fc *fileConfigurator) rawConfig() *osmConfig {
+	return fc.get()
+}
+
+func (fc *fileConfigurator) GetTracingEndpoint() (host string, port int, endpoint string) {
+	conf := fc.get()
+	return conf.TracingHost, conf.TracingPort, conf.TracingEndpoint
+}
+
+func (fc *fileConfigurator) GetMeshCIDRRanges() []string {
+	return fc.get().MeshCIDRRanges
+}
+
+func (fc *fileConfigurator) GetEnvoyLogLevel() string {
+	if level := fc.get().EnvoyLogLevel; level != "" {
+		return level
+	}
+	return defaultEnvoyLogLevel
+}
+
+func (fc *fileConfigurator) GetServiceCertValidityDuration() time.Duration {
+	raw := fc.get().ServiceCertValidityDuration
+	if raw == "" {
+		return defaultServiceCertValidityDuration
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing service_cert_validity_duration=%s, using default=%s", raw, defaultServiceCertValidityDuration)
+		return defaultServiceCertValidityDuration
+	}
+	return duration
+}
+
+func (fc *fileConfigurator) GetEgressPolicy() EgressMode {
+	if policy := EgressMode(fc.get().EgressPolicy); policy != "" {
+		return policy
+	}
+	return defaultEgressPolicy
+}
+
+func (fc *fileConfigurator) GetAnnouncementsChannel() <-chan interface{} {
+	return fc.announcements
+}
+
+// Subscribe is not yet implemented for the file-backed provider: it has no field-level diffing, so subscribers
+// receive no events. Callers should subscribe against the ConfigMap provider when one is configured.
+func (fc *fileConfigurator) Subscribe(keys ...string) (<-chan ConfigChange, func()) {
+	ch := make(chan ConfigChange)
+	return ch, func() { close(ch) }
+}
@@ -0,0 +1,235 @@
+package configurator
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment variable names consulted by LayeredConfigurator ahead of any configured source. These always take
+// precedence, letting an operator override a ConfigMap or file value without editing it.
+const (
+	envPermissiveTrafficPolicyMode = "OSM_PERMISSIVE_TRAFFIC_POLICY_MODE"
+	envTracingHost                 = "OSM_TRACING_HOST"
+	envTracingPort                 = "OSM_TRACING_PORT"
+	envTracingEndpoint             = "OSM_TRACING_ENDPOINT"
+	envMeshCIDRRanges              = "OSM_MESH_CIDR_RANGES"
+	envEnvoyLogLevel               = "OSM_ENVOY_LOG_LEVEL"
+	envServiceCertValidityDuration = "OSM_SERVICE_CERT_VALIDITY_DURATION"
+	envEgressPolicy                = "OSM_EGRESS_POLICY"
+)
+
+// LayeredConfigurator merges one or more Configurator sources into a single view: environment variables are
+// consulted first, then sources in the order given (highest precedence first), and finally the package's
+// built-in defaults.
+type LayeredConfigurator struct {
+	// sources is ordered from highest to lowest precedence, excluding environment variables which always win.
+	// defaultsConfigurator{} is always appended as the floor of the chain.
+	sources []Configurator
+}
+
+// NewLayeredConfigurator composes sources (highest precedence first) behind environment variable overrides.
+func NewLayeredConfigurator(sources ...Configurator) *LayeredConfigurator {
+	return &LayeredConfigurator{sources: append(sources, defaultsConfigurator{})}
+}
+
+// rawConfigProvider is implemented by Configurator sources that can expose their undecorated osmConfig. Several of
+// the typed getters below need it to tell "this source never set the field" apart from "this source explicitly set
+// the field to the same value as the package default" -- a distinction the already-defaulted typed getters alone
+// cannot make. Sources that don't implement it (e.g. defaultsConfigurator) are simply skipped when walking raw
+// fields; the floor of the chain is still reached through the final default return.
+type rawConfigProvider interface {
+	rawConfig() *osmConfig
+}
+
+// GetPermissiveTrafficPolicyMode implements Configurator.
+func (l *LayeredConfigurator) GetPermissiveTrafficPolicyMode() bool {
+	if raw, ok := os.LookupEnv(envPermissiveTrafficPolicyMode); ok {
+		if v, err := strconv.ParseBool(raw); err == nil {
+			return v
+		}
+		log.Error().Msgf("Error parsing %s=%s as a bool", envPermissiveTrafficPolicyMode, raw)
+	}
+
+	for _, source := range l.sources {
+		provider, ok := source.(rawConfigProvider)
+		if !ok {
+			continue
+		}
+		if mode := provider.rawConfig().PermissiveTrafficPolicyMode; mode != nil {
+			return *mode
+		}
+	}
+	return false
+}
+
+// GetTracingEndpoint implements Configurator.
+func (l *LayeredConfigurator) GetTracingEndpoint() (host string, port int, endpoint string) {
+	if host, ok := os.LookupEnv(envTracingHost); ok {
+		port, _ = strconv.Atoi(os.Getenv(envTracingPort))
+		return host, port, os.Getenv(envTracingEndpoint)
+	}
+
+	for _, source := range l.sources {
+		if host, port, endpoint = source.GetTracingEndpoint(); host != "" {
+			return host, port, endpoint
+		}
+	}
+	return "", 0, ""
+}
+
+// GetMeshCIDRRanges implements Configurator.
+func (l *LayeredConfigurator) GetMeshCIDRRanges() []string {
+	if raw, ok := os.LookupEnv(envMeshCIDRRanges); ok {
+		return strings.Split(raw, ",")
+	}
+
+	for _, source := range l.sources {
+		if ranges := source.GetMeshCIDRRanges(); len(ranges) > 0 {
+			return ranges
+		}
+	}
+	return nil
+}
+
+// GetEnvoyLogLevel implements Configurator.
+func (l *LayeredConfigurator) GetEnvoyLogLevel() string {
+	if level, ok := os.LookupEnv(envEnvoyLogLevel); ok {
+		return level
+	}
+
+	for _, source := range l.sources {
+		provider, ok := source.(rawConfigProvider)
+		if !ok {
+			continue
+		}
+		if level := provider.rawConfig().EnvoyLogLevel; level != "" {
+			return level
+		}
+	}
+	return defaultEnvoyLogLevel
+}
+
+// GetServiceCertValidityDuration implements Configurator.
+func (l *LayeredConfigurator) GetServiceCertValidityDuration() time.Duration {
+	if raw, ok := os.LookupEnv(envServiceCertValidityDuration); ok {
+		if d, err := time.ParseDuration(raw); err == nil {
+			return d
+		}
+		log.Error().Msgf("Error parsing %s=%s as a duration", envServiceCertValidityDuration, raw)
+	}
+
+	for _, source := range l.sources {
+		provider, ok := source.(rawConfigProvider)
+		if !ok {
+			continue
+		}
+		raw := provider.rawConfig().ServiceCertValidityDuration
+		if raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error parsing service_cert_validity_duration=%s, trying next source", raw)
+			continue
+		}
+		return d
+	}
+	return defaultServiceCertValidityDuration
+}
+
+// GetEgressPolicy implements Configurator.
+func (l *LayeredConfigurator) GetEgressPolicy() EgressMode {
+	if raw, ok := os.LookupEnv(envEgressPolicy); ok {
+		return EgressMode(raw)
+	}
+
+	for _, source := range l.sources {
+		provider, ok := source.(rawConfigProvider)
+		if !ok {
+			continue
+		}
+		if policy := provider.rawConfig().EgressPolicy; policy != "" {
+			return EgressMode(policy)
+		}
+	}
+	return defaultEgressPolicy
+}
+
+// GetAnnouncementsChannel returns the announcements channel of the first configured source, or nil if none was
+// configured.
+func (l *LayeredConfigurator) GetAnnouncementsChannel() <-chan interface{} {
+	for _, source := range l.sources {
+		if ch := source.GetAnnouncementsChannel(); ch != nil {
+			return ch
+		}
+	}
+	return nil
+}
+
+// Subscribe fans out every configured source's Subscribe into a single channel, since a field can change in any
+// one of them. Per the Configurator contract, the returned func closes the returned channel: it unsubscribes from
+// every source first, then waits for their now-closing channels to drain before closing out, so a subscriber
+// ranging over out never sees it close while a source is still able to send on it.
+func (l *LayeredConfigurator) Subscribe(keys ...string) (<-chan ConfigChange, func()) {
+	out := make(chan ConfigChange, subscriberChannelSize)
+
+	var wg sync.WaitGroup
+	var unsubscribes []func()
+	for _, source := range l.sources {
+		ch, unsubscribe := source.Subscribe(keys...)
+		unsubscribes = append(unsubscribes, unsubscribe)
+
+		wg.Add(1)
+		go func(ch <-chan ConfigChange) {
+			defer wg.Done()
+			for change := range ch {
+				out <- change
+			}
+		}(ch)
+	}
+
+	var closeOnce sync.Once
+	unsubscribe := func() {
+		closeOnce.Do(func() {
+			for _, u := range unsubscribes {
+				u()
+			}
+			go func() {
+				wg.Wait()
+				close(out)
+			}()
+		})
+	}
+	return out, unsubscribe
+}
+
+// defaultsConfigurator is the floor of the precedence chain: it returns the package's built-in defaults for every
+// tunable and never reports a live reload.
+type defaultsConfigurator struct{}
+
+func (defaultsConfigurator) GetPermissiveTrafficPolicyMode() bool { return false }
+
+func (defaultsConfigurator) GetTracingEndpoint() (host string, port int, endpoint string) {
+	return "", 0, ""
+}
+
+func (defaultsConfigurator) GetMeshCIDRRanges() []string { return nil }
+
+func (defaultsConfigurator) GetEnvoyLogLevel() string { return defaultEnvoyLogLevel }
+
+func (defaultsConfigurator) GetServiceCertValidityDuration() time.Duration {
+	return defaultServiceCertValidityDuration
+}
+
+func (defaultsConfigurator) GetEgressPolicy() EgressMode { return defaultEgressPolicy }
+
+func (defaultsConfigurator) GetAnnouncementsChannel() <-chan interface{} { return nil }
+
+func (defaultsConfigurator) Subscribe(keys ...string) (<-chan ConfigChange, func()) {
+	ch := make(chan ConfigChange)
+	close(ch)
+	return ch, func() {}
+}
@@ -0,0 +1,142 @@
+package configurator
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeConfiguratorSource is a minimal Configurator used to exercise LayeredConfigurator's precedence rules without
+// standing up a real ConfigMap or file-backed provider. It implements rawConfigProvider exactly as *Client and
+// *fileConfigurator do; a nil raw simulates a source that never sets anything.
+type fakeConfiguratorSource struct {
+	raw *osmConfig
+}
+
+func (f fakeConfiguratorSource) GetPermissiveTrafficPolicyMode() bool {
+	if f.raw != nil && f.raw.PermissiveTrafficPolicyMode != nil {
+		return *f.raw.PermissiveTrafficPolicyMode
+	}
+	return false
+}
+
+func (f fakeConfiguratorSource) GetTracingEndpoint() (host string, port int, endpoint string) {
+	if f.raw == nil {
+		return "", 0, ""
+	}
+	return f.raw.TracingHost, f.raw.TracingPort, f.raw.TracingEndpoint
+}
+
+func (f fakeConfiguratorSource) GetMeshCIDRRanges() []string {
+	if f.raw == nil {
+		return nil
+	}
+	return f.raw.MeshCIDRRanges
+}
+
+func (f fakeConfiguratorSource) GetEnvoyLogLevel() string {
+	if f.raw == nil || f.raw.EnvoyLogLevel == "" {
+		return defaultEnvoyLogLevel
+	}
+	return f.raw.EnvoyLogLevel
+}
+
+func (f fakeConfiguratorSource) GetServiceCertValidityDuration() time.Duration {
+	if f.raw == nil || f.raw.ServiceCertValidityDuration == "" {
+		return defaultServiceCertValidityDuration
+	}
+	d, _ := time.ParseDuration(f.raw.ServiceCertValidityDuration)
+	return d
+}
+
+func (f fakeConfiguratorSource) GetEgressPolicy() EgressMode {
+	if f.raw == nil || f.raw.EgressPolicy == "" {
+		return defaultEgressPolicy
+	}
+	return EgressMode(f.raw.EgressPolicy)
+}
+
+func (f fakeConfiguratorSource) GetAnnouncementsChannel() <-chan interface{} { return nil }
+
+func (f fakeConfiguratorSource) Subscribe(keys ...string) (<-chan ConfigChange, func()) {
+	ch := make(chan ConfigChange)
+	close(ch)
+	return ch, func() {}
+}
+
+func (f fakeConfiguratorSource) rawConfig() *osmConfig {
+	if f.raw == nil {
+		return &osmConfig{}
+	}
+	return f.raw
+}
+
+func TestLayeredConfiguratorPermissiveTrafficPolicyModePrecedence(t *testing.T) {
+	trueVal, falseVal := true, false
+
+	tests := []struct {
+		name     string
+		sources  []Configurator
+		expected bool
+	}{
+		{
+			name: "an explicit false in the higher-precedence source beats an explicit true in a lower one",
+			sources: []Configurator{
+				fakeConfiguratorSource{raw: &osmConfig{PermissiveTrafficPolicyMode: &falseVal}},
+				fakeConfiguratorSource{raw: &osmConfig{PermissiveTrafficPolicyMode: &trueVal}},
+			},
+			expected: false,
+		},
+		{
+			name: "an unset higher-precedence source falls through to a lower one",
+			sources: []Configurator{
+				fakeConfiguratorSource{raw: &osmConfig{}},
+				fakeConfiguratorSource{raw: &osmConfig{PermissiveTrafficPolicyMode: &trueVal}},
+			},
+			expected: true,
+		},
+		{
+			name:     "no source sets it, so the package default applies",
+			sources:  []Configurator{fakeConfiguratorSource{raw: &osmConfig{}}},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := NewLayeredConfigurator(test.sources...)
+			if got := l.GetPermissiveTrafficPolicyMode(); got != test.expected {
+				t.Errorf("GetPermissiveTrafficPolicyMode() = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestLayeredConfiguratorEnvoyLogLevelExplicitDefaultWins(t *testing.T) {
+	// A higher-precedence source that explicitly sets the same value as the package default must still win over a
+	// lower-precedence source with a different value -- comparing against the already-defaulted typed getter can't
+	// tell "explicitly set to the default" apart from "never set".
+	l := NewLayeredConfigurator(
+		fakeConfiguratorSource{raw: &osmConfig{EnvoyLogLevel: defaultEnvoyLogLevel}},
+		fakeConfiguratorSource{raw: &osmConfig{EnvoyLogLevel: "debug"}},
+	)
+
+	if got := l.GetEnvoyLogLevel(); got != defaultEnvoyLogLevel {
+		t.Errorf("GetEnvoyLogLevel() = %q, want %q", got, defaultEnvoyLogLevel)
+	}
+}
+
+func TestLayeredConfiguratorSubscribeClosesOutputChannel(t *testing.T) {
+	l := NewLayeredConfigurator(fakeConfiguratorSource{raw: &osmConfig{}})
+
+	out, unsubscribe := l.Subscribe()
+	unsubscribe()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Fatal("expected the channel returned by Subscribe to be closed, got a ConfigChange instead")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Subscribe's channel to close after unsubscribe")
+	}
+}
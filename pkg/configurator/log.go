@@ -0,0 +1,7 @@
+package configurator
+
+import (
+	"github.com/open-service-mesh/osm/pkg/logger"
+)
+
+var log = logger.New("configurator")
@@ -0,0 +1,43 @@
+package configurator
+
+import (
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Sources selects which Configurator providers NewConfigurator composes into the LayeredConfigurator it returns.
+// Enabled providers are layered highest-precedence first: the ConfigMap provider (when enabled) is always
+// consulted before the file provider, regardless of the order of the fields below.
+type Sources struct {
+	// ConfigMap enables the Kubernetes "osm-config" ConfigMap provider, which live-reloads via an informer.
+	ConfigMap bool
+
+	// File enables the YAML file-backed provider, which live-reloads via fsnotify. Useful in air-gapped or
+	// CRD-less environments where the ConfigMap informer cannot be used.
+	File bool
+
+	// FilePath is the path to the YAML file read when File is enabled.
+	FilePath string
+}
+
+// NewConfigurator creates a Configurator backed by the providers selected in sources, composed into a
+// LayeredConfigurator behind environment variable overrides (env vars > ConfigMap/MeshConfig > file > defaults).
+// dynamicClient is used to watch the "osm-mesh-config" MeshConfig CR alongside the ConfigMap provider.
+func NewConfigurator(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, stop chan struct{}, osmNamespace, osmConfigMapName string, sources Sources) Configurator {
+	var providers []Configurator
+
+	if sources.ConfigMap {
+		providers = append(providers, newConfigMapConfigurator(kubeClient, dynamicClient, stop, osmNamespace, osmConfigMapName))
+	}
+
+	if sources.File {
+		fileConfigurator, err := newFileConfigurator(sources.FilePath, stop)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error starting file-backed Configurator for %s; continuing without it", sources.FilePath)
+		} else {
+			providers = append(providers, fileConfigurator)
+		}
+	}
+
+	return NewLayeredConfigurator(providers...)
+}
@@ -0,0 +1,115 @@
+package configurator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// subscriberChannelSize is the buffer depth of each channel returned by Subscribe. It lets a slow subscriber fall
+// behind by up to this many ConfigChange events without blocking the watcher goroutine that dispatches them; once
+// the buffer is full, further events for that subscriber are dropped.
+const subscriberChannelSize = 10
+
+// ConfigChange describes a single field that changed in the "osm-config" ConfigMap between two observed versions.
+type ConfigChange struct {
+	// Field is the yaml tag of the osmConfig field that changed, e.g. "permissive_traffic_policy_mode".
+	Field string
+
+	// OldValue is the value of Field before the change.
+	OldValue interface{}
+
+	// NewValue is the value of Field after the change.
+	NewValue interface{}
+}
+
+// Subscribe registers the caller to receive a ConfigChange whenever one of the given osm-config fields is updated.
+// Passing no keys subscribes to every field. The returned func unsubscribes and closes the returned channel.
+func (c *Client) Subscribe(keys ...string) (<-chan ConfigChange, func()) {
+	if len(keys) == 0 {
+		keys = allConfigKeys()
+	}
+
+	ch := make(chan ConfigChange, subscriberChannelSize)
+
+	c.subscribersMutex.Lock()
+	for _, key := range keys {
+		if c.subscribers[key] == nil {
+			c.subscribers[key] = make(map[chan ConfigChange]struct{})
+		}
+		c.subscribers[key][ch] = struct{}{}
+	}
+	c.subscribersMutex.Unlock()
+
+	unsubscribe := func() {
+		c.subscribersMutex.Lock()
+		defer c.subscribersMutex.Unlock()
+		for _, key := range keys {
+			delete(c.subscribers[key], ch)
+		}
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// dispatch fans a ConfigChange out to every subscriber registered for change.Field.
+func (c *Client) dispatch(change ConfigChange) {
+	c.subscribersMutex.RLock()
+	defer c.subscribersMutex.RUnlock()
+
+	for ch := range c.subscribers[change.Field] {
+		select {
+		case ch <- change:
+		default:
+			log.Warn().Msgf("Subscriber channel full for osm-config key=%s; dropping change notification", change.Field)
+		}
+	}
+}
+
+// yamlFieldName returns the key portion of an osmConfig field's yaml tag, stripping options like ",omitempty" --
+// e.g. the tag `yaml:"permissive_traffic_policy_mode,omitempty"` yields "permissive_traffic_policy_mode", matching
+// what ConfigMap Data keys and Subscribe callers use.
+func yamlFieldName(field reflect.StructField) string {
+	return strings.SplitN(field.Tag.Get("yaml"), ",", 2)[0]
+}
+
+// allConfigKeys returns the yaml tag (options stripped) of every field on osmConfig, used when a subscriber wants
+// to hear about all of them.
+func allConfigKeys() []string {
+	t := reflect.TypeOf(osmConfig{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		keys = append(keys, yamlFieldName(t.Field(i)))
+	}
+	return keys
+}
+
+// diffOsmConfig compares two osmConfig values field by field (using their yaml tags) and returns a ConfigChange for
+// each field whose value differs. A nil old or new config is treated as the zero value.
+func diffOsmConfig(old, new *osmConfig) []ConfigChange {
+	if old == nil {
+		old = &osmConfig{}
+	}
+	if new == nil {
+		new = &osmConfig{}
+	}
+
+	var changes []ConfigChange
+	oldVal := reflect.ValueOf(*old)
+	newVal := reflect.ValueOf(*new)
+	t := oldVal.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		oldField := oldVal.Field(i).Interface()
+		newField := newVal.Field(i).Interface()
+		if !reflect.DeepEqual(oldField, newField) {
+			changes = append(changes, ConfigChange{
+				Field:    yamlFieldName(t.Field(i)),
+				OldValue: oldField,
+				NewValue: newField,
+			})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,148 @@
+package configurator
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestClient() *Client {
+	return &Client{subscribers: make(map[string]map[chan ConfigChange]struct{})}
+}
+
+func TestAllConfigKeysStripsYamlTagOptions(t *testing.T) {
+	// permissive_traffic_policy_mode carries a yaml tag of "permissive_traffic_policy_mode,omitempty" -- a regression
+	// here would return it with the ",omitempty" suffix still attached, which would never match what a caller
+	// passes to Subscribe.
+	for _, key := range allConfigKeys() {
+		if strings.Contains(key, ",") {
+			t.Errorf("allConfigKeys() returned %q, which still carries yaml tag options", key)
+		}
+	}
+
+	found := false
+	for _, key := range allConfigKeys() {
+		if key == "permissive_traffic_policy_mode" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error(`allConfigKeys() did not return "permissive_traffic_policy_mode"`)
+	}
+}
+
+func TestDiffOsmConfig(t *testing.T) {
+	trueVal := true
+
+	old := &osmConfig{
+		EnvoyLogLevel: "info",
+	}
+	new := &osmConfig{
+		PermissiveTrafficPolicyMode: &trueVal,
+		EnvoyLogLevel:               "debug",
+	}
+
+	changes := diffOsmConfig(old, new)
+
+	byField := make(map[string]ConfigChange, len(changes))
+	for _, change := range changes {
+		byField[change.Field] = change
+	}
+
+	permissiveChange, ok := byField["permissive_traffic_policy_mode"]
+	if !ok {
+		t.Fatalf("diffOsmConfig() did not report a change for permissive_traffic_policy_mode; changes=%+v", changes)
+	}
+	if permissiveChange.OldValue != (*bool)(nil) {
+		t.Errorf("expected OldValue=nil for permissive_traffic_policy_mode, got %v", permissiveChange.OldValue)
+	}
+
+	logLevelChange, ok := byField["envoy_log_level"]
+	if !ok {
+		t.Fatalf("diffOsmConfig() did not report a change for envoy_log_level; changes=%+v", changes)
+	}
+	if logLevelChange.OldValue != "info" || logLevelChange.NewValue != "debug" {
+		t.Errorf("unexpected envoy_log_level change: %+v", logLevelChange)
+	}
+
+	if len(changes) != 2 {
+		t.Errorf("expected exactly 2 changed fields, got %d: %+v", len(changes), changes)
+	}
+}
+
+func TestDiffOsmConfigNoChanges(t *testing.T) {
+	conf := &osmConfig{EnvoyLogLevel: "debug"}
+	if changes := diffOsmConfig(conf, conf); len(changes) != 0 {
+		t.Errorf("expected no changes comparing a config against itself, got %+v", changes)
+	}
+}
+
+func TestDiffOsmConfigTreatsNilAsZeroValue(t *testing.T) {
+	new := &osmConfig{EnvoyLogLevel: "debug"}
+	changes := diffOsmConfig(nil, new)
+
+	if len(changes) != 1 || changes[0].Field != "envoy_log_level" {
+		t.Errorf("expected a single envoy_log_level change against a nil old config, got %+v", changes)
+	}
+}
+
+func TestClientSubscribeFiltersByKey(t *testing.T) {
+	c := newTestClient()
+
+	ch, unsubscribe := c.Subscribe("envoy_log_level")
+	defer unsubscribe()
+
+	c.dispatch(ConfigChange{Field: "egress_policy", OldValue: "disabled", NewValue: "allow_all"})
+	c.dispatch(ConfigChange{Field: "envoy_log_level", OldValue: "info", NewValue: "debug"})
+
+	select {
+	case change := <-ch:
+		if change.Field != "envoy_log_level" {
+			t.Errorf("expected to only receive envoy_log_level changes, got %+v", change)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the subscribed-to change")
+	}
+
+	select {
+	case change := <-ch:
+		t.Fatalf("received an unexpected second change: %+v", change)
+	default:
+	}
+}
+
+func TestClientSubscribeAllSubscribesToEveryKey(t *testing.T) {
+	c := newTestClient()
+
+	ch, unsubscribe := c.Subscribe()
+	defer unsubscribe()
+
+	for _, key := range allConfigKeys() {
+		c.dispatch(ConfigChange{Field: key, OldValue: "old", NewValue: "new"})
+
+		select {
+		case change := <-ch:
+			if change.Field != key {
+				t.Errorf("expected a change for %q, got %+v", key, change)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for a change on key %q after Subscribe()", key)
+		}
+	}
+}
+
+func TestClientUnsubscribeClosesChannel(t *testing.T) {
+	c := newTestClient()
+
+	ch, unsubscribe := c.Subscribe("envoy_log_level")
+	unsubscribe()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected the channel returned by Subscribe to be closed, got a ConfigChange instead")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Subscribe's channel to close after unsubscribe")
+	}
+}
@@ -0,0 +1,63 @@
+package configurator
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+// Configurator is the controller interface for the OSM "osm-config" ConfigMap, exposing the mesh's runtime tunables.
+type Configurator interface {
+	// GetPermissiveTrafficPolicyMode tells us whether the OSM Control Plane is configured to run in permissive mode,
+	// allowing existing traffic between services to flow as-is, ignoring SMI policy.
+	GetPermissiveTrafficPolicyMode() bool
+
+	// GetTracingEndpoint returns the host, port and HTTP endpoint of the tracing collector configured for the mesh.
+	GetTracingEndpoint() (host string, port int, endpoint string)
+
+	// GetMeshCIDRRanges returns the list of CIDR ranges considered to be inside the mesh.
+	GetMeshCIDRRanges() []string
+
+	// GetEnvoyLogLevel returns the configured Envoy log level.
+	GetEnvoyLogLevel() string
+
+	// GetServiceCertValidityDuration returns how long a service certificate issued by OSM remains valid.
+	GetServiceCertValidityDuration() time.Duration
+
+	// GetEgressPolicy returns the mesh's configured egress mode.
+	GetEgressPolicy() EgressMode
+
+	// GetAnnouncementsChannel returns the channel on which ConfigMap changes are announced.
+	GetAnnouncementsChannel() <-chan interface{}
+
+	// Subscribe registers the caller for change notifications on the given osm-config fields, identified by their
+	// yaml tag (e.g. "permissive_traffic_policy_mode"). Passing no keys subscribes to every field. The returned
+	// func unsubscribes and closes the channel.
+	Subscribe(keys ...string) (<-chan ConfigChange, func())
+}
+
+// Client is the type used to represent the Kubernetes client for the "osm-config" ConfigMap and the
+// "osm-mesh-config" MeshConfig CR.
+type Client struct {
+	informer           cache.SharedIndexInformer
+	cache              cache.Store
+	meshConfigInformer cache.SharedIndexInformer
+	meshConfigCache    cache.Store
+	cacheSynced        chan interface{}
+	announcements      chan interface{}
+	osmNamespace       string
+	osmConfigMapName   string
+
+	// configMutex guards lastConfig, which is the most recently decoded osmConfig observed from the informer.
+	configMutex sync.RWMutex
+	lastConfig  *osmConfig
+
+	// subscribersMutex guards subscribers, the set of channels registered via Subscribe, keyed by osmConfig yaml tag.
+	subscribersMutex sync.RWMutex
+	subscribers      map[string]map[chan ConfigChange]struct{}
+
+	// recorder emits a Kubernetes Event on the "osm-config" ConfigMap whenever an update is rejected by Validate.
+	recorder record.EventRecorder
+}
@@ -0,0 +1,69 @@
+package configurator
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// EgressMode determines how the mesh treats traffic to destinations outside the mesh.
+type EgressMode string
+
+const (
+	// EgressModeDisabled blocks all traffic to destinations outside the mesh.
+	EgressModeDisabled EgressMode = "disabled"
+
+	// EgressModeAllowAll permits traffic to any destination outside the mesh.
+	EgressModeAllowAll EgressMode = "allow_all"
+
+	// EgressModeAllowListed permits traffic only to destinations explicitly allow-listed by an egress policy.
+	EgressModeAllowListed EgressMode = "allow_listed"
+)
+
+// Defaults applied when the corresponding osmConfig field is unset.
+const (
+	defaultEnvoyLogLevel               = "info"
+	defaultServiceCertValidityDuration = 24 * time.Hour
+	defaultEgressPolicy                = EgressModeDisabled
+)
+
+var validEnvoyLogLevels = map[string]bool{
+	"trace": true, "debug": true, "info": true, "warning": true, "error": true, "critical": true, "off": true,
+}
+
+var validEgressModes = map[EgressMode]bool{
+	EgressModeDisabled:    true,
+	EgressModeAllowAll:    true,
+	EgressModeAllowListed: true,
+}
+
+// Validate checks that conf describes a usable "osm-config" ConfigMap. A ConfigMap that fails validation is
+// rejected in favor of the last-known-good configuration, mirroring how a malformed Kubernetes resource is
+// rejected by admission rather than torn down.
+func Validate(conf *osmConfig) error {
+	if conf.EnvoyLogLevel != "" && !validEnvoyLogLevels[conf.EnvoyLogLevel] {
+		return fmt.Errorf("invalid envoy_log_level %q", conf.EnvoyLogLevel)
+	}
+
+	if conf.ServiceCertValidityDuration != "" {
+		if _, err := time.ParseDuration(conf.ServiceCertValidityDuration); err != nil {
+			return fmt.Errorf("invalid service_cert_validity_duration %q: %w", conf.ServiceCertValidityDuration, err)
+		}
+	}
+
+	if conf.EgressPolicy != "" && !validEgressModes[EgressMode(conf.EgressPolicy)] {
+		return fmt.Errorf("invalid egress_policy %q", conf.EgressPolicy)
+	}
+
+	for _, cidr := range conf.MeshCIDRRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid mesh_cidr_ranges entry %q: %w", cidr, err)
+		}
+	}
+
+	if conf.TracingPort < 0 || conf.TracingPort > 65535 {
+		return fmt.Errorf("invalid tracing_port %d", conf.TracingPort)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package configurator
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *osmConfig
+		wantErr bool
+	}{
+		{
+			name:    "zero-value config is valid",
+			conf:    &osmConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "valid envoy_log_level",
+			conf:    &osmConfig{EnvoyLogLevel: "debug"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid envoy_log_level",
+			conf:    &osmConfig{EnvoyLogLevel: "verbose"},
+			wantErr: true,
+		},
+		{
+			name:    "valid service_cert_validity_duration",
+			conf:    &osmConfig{ServiceCertValidityDuration: "24h"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid service_cert_validity_duration",
+			conf:    &osmConfig{ServiceCertValidityDuration: "not-a-duration"},
+			wantErr: true,
+		},
+		{
+			name:    "valid egress_policy",
+			conf:    &osmConfig{EgressPolicy: string(EgressModeAllowListed)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid egress_policy",
+			conf:    &osmConfig{EgressPolicy: "sometimes"},
+			wantErr: true,
+		},
+		{
+			name:    "valid mesh_cidr_ranges",
+			conf:    &osmConfig{MeshCIDRRanges: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid mesh_cidr_ranges entry",
+			conf:    &osmConfig{MeshCIDRRanges: []string{"not-a-cidr"}},
+			wantErr: true,
+		},
+		{
+			name:    "valid tracing_port",
+			conf:    &osmConfig{TracingPort: 9411},
+			wantErr: false,
+		},
+		{
+			name:    "negative tracing_port",
+			conf:    &osmConfig{TracingPort: -1},
+			wantErr: true,
+		},
+		{
+			name:    "tracing_port out of range",
+			conf:    &osmConfig{TracingPort: 70000},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.conf)
+			if test.wantErr && err == nil {
+				t.Error("Validate() = nil, want an error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("Validate() = %s, want nil", err)
+			}
+		})
+	}
+}